@@ -2,40 +2,83 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"api-test/internal/cache"
 	"api-test/internal/config"
 	"api-test/internal/database"
 	"api-test/internal/handler"
 	"api-test/internal/service"
+
+	"github.com/gorilla/mux"
 )
 
+// shutdownTimeout bounds how long the server waits for in-flight requests
+// to finish during a graceful shutdown.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	// Load configuration
 	cfg := config.Load()
 
 	// Initialize database
-	db := database.Connect(cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName)
-	defer db.Close()
+	db := database.Connect(cfg)
 
 	// Initialize Redis cache
 	redisCache := cache.New(cfg.RedisHost, cfg.RedisPort)
-	defer redisCache.Close()
 
 	// Initialize service layer
-	userService := service.New(db, redisCache)
+	userRepo := database.NewRepository(db, cfg.DBDriver)
+	userService := service.New(userRepo, redisCache, cfg.SessionTTL, cfg.AllUsersCacheTTL, cfg.UserCacheTTL, cfg.CacheJitterFrac)
 
 	// Initialize handlers
 	userHandler := handler.New(userService)
+	authHandler := handler.NewAuth(userService)
+	healthHandler := handler.NewHealth(db, redisCache)
 
 	// Register routes
-	handler.RegisterRoutes(userHandler)
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router, userHandler, authHandler, healthHandler, userService)
 
-	// Start server
 	addr := fmt.Sprintf(":%s", cfg.Port)
-	fmt.Printf("Server running on %s\n", addr)
-	log.Fatal(http.ListenAndServe(addr, nil))
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: router,
+	}
+
+	go func() {
+		slog.Info("server running", "addr", addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("server stopped", "error", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	slog.Info("shutting down server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Error("error during server shutdown", "error", err)
+	}
+
+	if err := db.Close(); err != nil {
+		slog.Error("error closing database", "error", err)
+	}
+	if err := redisCache.Close(); err != nil {
+		slog.Error("error closing redis", "error", err)
+	}
+
+	slog.Info("server exited")
 }
@@ -3,7 +3,8 @@ package cache
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -26,13 +27,19 @@ func New(host, port string) *Client {
 	defer cancel()
 
 	if err := client.Ping(ctx).Err(); err != nil {
-		log.Fatalf("Failed to connect to Redis: %v", err)
+		slog.Error("failed to connect to Redis", "error", err)
+		os.Exit(1)
 	}
 
-	log.Println("✓ Connected to Redis")
+	slog.Info("connected to Redis")
 	return &Client{client: client, Underlying: client}
 }
 
+// Ping checks that the Redis connection is alive.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
 // Get retrieves a value from cache.
 func (c *Client) Get(ctx context.Context, key string) (string, error) {
 	return c.client.Get(ctx, key).Result()
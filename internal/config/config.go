@@ -1,20 +1,39 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 // Config holds all application configuration.
 type Config struct {
 	// Database configuration
+	DBDriver   string
 	DBHost     string
 	DBPort     string
 	DBUser     string
 	DBPassword string
 	DBName     string
+	DBPath     string
+
+	// Connection pool configuration
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
 
 	// Redis configuration
 	RedisHost string
 	RedisPort string
 
+	// Auth configuration
+	SessionTTL time.Duration
+
+	// Cache configuration
+	AllUsersCacheTTL time.Duration
+	UserCacheTTL     time.Duration
+	CacheJitterFrac  float64
+
 	// Server configuration
 	Port string
 }
@@ -22,14 +41,27 @@ type Config struct {
 // Load loads configuration from environment variables with defaults.
 func Load() *Config {
 	return &Config{
+		DBDriver:   getEnv("DB_DRIVER", "postgres"),
 		DBHost:     getEnv("DB_HOST", "localhost"),
 		DBPort:     getEnv("DB_PORT", "5432"),
 		DBUser:     getEnv("DB_USER", "postgres"),
 		DBPassword: getEnv("DB_PASSWORD", "password"),
 		DBName:     getEnv("DB_NAME", "api_db"),
+		DBPath:     getEnv("DB_PATH", "api.db"),
+
+		DBMaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 25),
+		DBConnMaxLifetime: getEnvMinutes("DB_CONN_MAX_LIFETIME_MINUTES", 5),
+
 		RedisHost:  getEnv("REDIS_HOST", "localhost"),
 		RedisPort:  getEnv("REDIS_PORT", "6379"),
-		Port:       getEnv("PORT", "8080"),
+		SessionTTL: getEnvMinutes("SESSION_TTL_MINUTES", 60),
+
+		AllUsersCacheTTL: getEnvMinutes("ALL_USERS_CACHE_TTL_MINUTES", 5),
+		UserCacheTTL:     getEnvMinutes("USER_CACHE_TTL_MINUTES", 10),
+		CacheJitterFrac:  getEnvFloat("CACHE_JITTER_FRACTION", 0.10),
+
+		Port: getEnv("PORT", "8080"),
 	}
 }
 
@@ -40,3 +72,36 @@ func getEnv(key, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+// getEnvMinutes retrieves an environment variable as a duration expressed in
+// minutes, falling back to defaultVal (also in minutes) if unset or invalid.
+func getEnvMinutes(key string, defaultVal int) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if minutes, err := strconv.Atoi(value); err == nil {
+			return time.Duration(minutes) * time.Minute
+		}
+	}
+	return time.Duration(defaultVal) * time.Minute
+}
+
+// getEnvFloat retrieves an environment variable as a float64, falling back
+// to defaultVal if unset or invalid.
+func getEnvFloat(key string, defaultVal float64) float64 {
+	if value, exists := os.LookupEnv(key); exists {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultVal
+}
+
+// getEnvInt retrieves an environment variable as an int, falling back to
+// defaultVal if unset or invalid.
+func getEnvInt(key string, defaultVal int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultVal
+}
@@ -3,25 +3,63 @@ package database
 import (
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
+
+	"api-test/internal/config"
 
 	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
 )
 
-// Connect establishes a connection to PostgreSQL database.
-func Connect(host, port, user, password, dbname string) *sql.DB {
+// Connect establishes a connection to the database backend selected by
+// cfg.DBDriver ("postgres" or "sqlite") and tunes its connection pool.
+func Connect(cfg *config.Config) *sql.DB {
+	var db *sql.DB
+	if cfg.DBDriver == "sqlite" {
+		db = connectSQLite(cfg.DBPath)
+	} else {
+		db = connectPostgres(cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName)
+	}
+
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+
+	return db
+}
+
+func connectPostgres(host, port, user, password, dbname string) *sql.DB {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		host, port, user, password, dbname)
 
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		slog.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+
+	if err := db.Ping(); err != nil {
+		slog.Error("failed to ping database", "error", err)
+		os.Exit(1)
+	}
+
+	slog.Info("connected to PostgreSQL")
+	return db
+}
+
+func connectSQLite(path string) *sql.DB {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		slog.Error("failed to open sqlite database", "error", err)
+		os.Exit(1)
 	}
 
 	if err := db.Ping(); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+		slog.Error("failed to ping sqlite database", "error", err)
+		os.Exit(1)
 	}
 
-	log.Println("✓ Connected to PostgreSQL")
+	slog.Info("connected to SQLite")
 	return db
 }
@@ -0,0 +1,7 @@
+package database
+
+import "errors"
+
+// ErrNotFound is returned by a UserRepository when the requested row
+// does not exist.
+var ErrNotFound = errors.New("user not found")
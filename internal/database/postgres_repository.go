@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"api-test/internal/model"
+)
+
+// postgresRepository implements UserRepository against PostgreSQL.
+type postgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a UserRepository backed by PostgreSQL.
+func NewPostgresRepository(db *sql.DB) UserRepository {
+	return &postgresRepository{db: db}
+}
+
+func (r *postgresRepository) GetAll(ctx context.Context) ([]model.User, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, email, created_at, updated_at FROM users ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []model.User
+	for rows.Next() {
+		var user model.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *postgresRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
+	var user model.User
+	err := r.db.QueryRowContext(ctx,
+		"SELECT id, name, email, created_at, updated_at FROM users WHERE id = $1",
+		id,
+	).Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *postgresRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	err := r.db.QueryRowContext(ctx,
+		"SELECT id, name, email, password_hash, created_at, updated_at FROM users WHERE email = $1",
+		email,
+	).Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *postgresRepository) Create(ctx context.Context, user *model.User) error {
+	now := time.Now()
+	err := r.db.QueryRowContext(ctx,
+		"INSERT INTO users (name, email, password_hash, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		user.Name, user.Email, user.PasswordHash, now, now,
+	).Scan(&user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	return nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, user *model.User) error {
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE users SET name = $1, email = $2, updated_at = $3 WHERE id = $4",
+		user.Name, user.Email, now, user.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	user.UpdatedAt = now
+	return nil
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM users WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
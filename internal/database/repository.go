@@ -0,0 +1,28 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"api-test/internal/model"
+)
+
+// UserRepository abstracts user persistence so UserService can run against
+// different SQL backends (PostgreSQL in production, SQLite in tests)
+// without depending on a specific driver or dialect.
+type UserRepository interface {
+	GetAll(ctx context.Context) ([]model.User, error)
+	GetByID(ctx context.Context, id string) (*model.User, error)
+	GetByEmail(ctx context.Context, email string) (*model.User, error)
+	Create(ctx context.Context, user *model.User) error
+	Update(ctx context.Context, user *model.User) error
+	Delete(ctx context.Context, id string) error
+}
+
+// NewRepository builds the UserRepository implementation matching cfg.DBDriver.
+func NewRepository(db *sql.DB, driver string) UserRepository {
+	if driver == "sqlite" {
+		return NewSQLiteRepository(db)
+	}
+	return NewPostgresRepository(db)
+}
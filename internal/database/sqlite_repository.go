@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"api-test/internal/model"
+)
+
+// sqliteRepository implements UserRepository against SQLite.
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRepository creates a UserRepository backed by SQLite.
+func NewSQLiteRepository(db *sql.DB) UserRepository {
+	return &sqliteRepository{db: db}
+}
+
+func (r *sqliteRepository) GetAll(ctx context.Context) ([]model.User, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, email, created_at, updated_at FROM users ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []model.User
+	for rows.Next() {
+		var user model.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *sqliteRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
+	var user model.User
+	err := r.db.QueryRowContext(ctx,
+		"SELECT id, name, email, created_at, updated_at FROM users WHERE id = ?",
+		id,
+	).Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *sqliteRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	err := r.db.QueryRowContext(ctx,
+		"SELECT id, name, email, password_hash, created_at, updated_at FROM users WHERE email = ?",
+		email,
+	).Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *sqliteRepository) Create(ctx context.Context, user *model.User) error {
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx,
+		"INSERT INTO users (name, email, password_hash, created_at, updated_at) VALUES (?, ?, ?, ?, ?)",
+		user.Name, user.Email, user.PasswordHash, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	user.ID = int(id)
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	return nil
+}
+
+func (r *sqliteRepository) Update(ctx context.Context, user *model.User) error {
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE users SET name = ?, email = ?, updated_at = ? WHERE id = ?",
+		user.Name, user.Email, now, user.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	user.UpdatedAt = now
+	return nil
+}
+
+func (r *sqliteRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM users WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api-test/internal/model"
+	"api-test/internal/service"
+	"api-test/internal/validation"
+)
+
+// AuthHandler handles registration, login, and logout requests.
+type AuthHandler struct {
+	service *service.UserService
+}
+
+// NewAuth creates a new auth handler.
+func NewAuth(service *service.UserService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// loginRequest is the expected body for POST /login.
+type loginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Register handles POST requests to /register and creates a new user.
+//
+// Request Body:
+//
+//	Expects JSON with fields: name, email, password
+//
+// HTTP Response:
+//   - Status 201: Successfully created user, returns the created user as JSON
+//   - Status 400: Invalid request body, unknown fields, or failed validation
+//   - Content-Type: application/json
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var user model.User
+	if err := decodeJSONBody(w, r, &user); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Bad Request", "invalid request body", r.URL.Path)
+		return
+	}
+
+	if err := validation.Struct(user); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Validation Failed", err.Error(), r.URL.Path)
+		return
+	}
+
+	if err := h.service.Create(r.Context(), &user); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Bad Request", err.Error(), r.URL.Path)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(user)
+}
+
+// Login handles POST requests to /login and issues a session token.
+//
+// Request Body:
+//
+//	Expects JSON with fields: email, password
+//
+// HTTP Response:
+//   - Status 200: Successfully authenticated, returns {"token": "..."}
+//   - Status 400: Invalid request body, unknown fields, or failed validation
+//   - Status 401: Invalid credentials
+//   - Content-Type: application/json
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := decodeJSONBody(w, r, &req); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Bad Request", "invalid request body", r.URL.Path)
+		return
+	}
+
+	if err := validation.Struct(req); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Validation Failed", err.Error(), r.URL.Path)
+		return
+	}
+
+	token, err := h.service.Authenticate(r.Context(), req.Email, req.Password)
+	if err != nil {
+		writeProblem(w, http.StatusUnauthorized, "Unauthorized", "invalid credentials", r.URL.Path)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
+
+// Logout handles POST requests to /logout and revokes the caller's session token.
+//
+// HTTP Response:
+//   - Status 204: Token revoked (or already invalid)
+//   - Status 401: Missing or malformed Authorization header
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(r)
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	_ = h.service.Logout(r.Context(), token)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// bearerToken extracts the token from a "Bearer <token>" Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	auth := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(auth, prefix) {
+		return "", false
+	}
+	token := strings.TrimPrefix(auth, prefix)
+	if token == "" {
+		return "", false
+	}
+	return token, true
+}
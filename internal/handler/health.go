@@ -1,16 +1,60 @@
 package handler
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"net/http"
+	"time"
+
+	"api-test/internal/cache"
 )
 
-// Health handles GET requests to /health and returns API status.
+// readyTimeout bounds how long Readyz waits on its dependency pings.
+const readyTimeout = 2 * time.Second
+
+// HealthHandler serves liveness and readiness probes.
+type HealthHandler struct {
+	db    *sql.DB
+	cache *cache.Client
+}
+
+// NewHealth creates a new health handler.
+func NewHealth(db *sql.DB, cache *cache.Client) *HealthHandler {
+	return &HealthHandler{db: db, cache: cache}
+}
+
+// Livez handles GET requests to /livez and reports whether the process is
+// up, without checking any dependency.
 //
 // HTTP Response:
-//   - Status 200: API is healthy
+//   - Status 200: Process is up
 //   - Content-Type: application/json
-func Health(w http.ResponseWriter, r *http.Request) {
+func (h *HealthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// Readyz handles GET requests to /readyz and reports whether the service
+// is ready to serve traffic by pinging the database and Redis.
+//
+// HTTP Response:
+//   - Status 200: Database and Redis are both reachable, Content-Type: application/json
+//   - Status 503: Database or Redis is unreachable, Content-Type: application/problem+json
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+	defer cancel()
+
+	if err := h.db.PingContext(ctx); err != nil {
+		writeProblem(w, http.StatusServiceUnavailable, "Service Unavailable", "database unreachable", r.URL.Path)
+		return
+	}
+
+	if err := h.cache.Ping(ctx); err != nil {
+		writeProblem(w, http.StatusServiceUnavailable, "Service Unavailable", "cache unreachable", r.URL.Path)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 }
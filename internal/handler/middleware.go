@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"api-test/internal/model"
+	"api-test/internal/service"
+)
+
+// contextKey is an unexported type to avoid collisions with context keys
+// defined in other packages.
+type contextKey string
+
+// userContextKey is the context key under which the authenticated user is stored.
+const userContextKey contextKey = "user"
+
+// RequireAuth returns middleware that resolves a Bearer session token to a
+// user via userService and injects it into the request context, rejecting
+// the request with 401 if the token is missing or invalid.
+func RequireAuth(userService *service.UserService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				writeProblem(w, http.StatusUnauthorized, "Unauthorized", "missing or invalid authorization header", r.URL.Path)
+				return
+			}
+
+			user, err := userService.ResolveToken(r.Context(), token)
+			if err != nil {
+				writeProblem(w, http.StatusUnauthorized, "Unauthorized", "invalid or expired session", r.URL.Path)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userContextKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserFromContext returns the authenticated user injected by RequireAuth.
+func UserFromContext(ctx context.Context) (*model.User, bool) {
+	user, ok := ctx.Value(userContextKey).(*model.User)
+	return user, ok
+}
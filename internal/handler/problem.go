@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// maxRequestBodyBytes caps the size of request bodies accepted by handlers
+// that decode JSON, guarding against oversized payloads.
+const maxRequestBodyBytes = 1 << 20 // 1 MiB
+
+// problem is an RFC 7807 "Problem Details for HTTP APIs" response body.
+type problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// writeProblem writes an RFC 7807 application/problem+json response with
+// the given status, title, and detail. instance is typically the request
+// path and may be left empty.
+func writeProblem(w http.ResponseWriter, status int, title, detail, instance string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problem{
+		Type:     "about:blank",
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+	})
+}
+
+// decodeJSONBody caps r.Body at maxRequestBodyBytes and decodes it into v,
+// rejecting unknown fields.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
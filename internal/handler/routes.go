@@ -1,20 +1,33 @@
 package handler
 
-import "net/http"
-
-// RegisterRoutes registers all API routes.
-func RegisterRoutes(userHandler *UserHandler) {
-	http.HandleFunc("/health", Health)
-
-	http.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodGet {
-			userHandler.GetAll(w, r)
-		} else if r.Method == http.MethodPost {
-			userHandler.Create(w, r)
-		} else {
-			w.WriteHeader(http.StatusMethodNotAllowed)
-		}
-	})
-
-	http.HandleFunc("/user", userHandler.GetByID)
+import (
+	"net/http"
+
+	"api-test/internal/middleware"
+	"api-test/internal/service"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// RegisterRoutes registers all API routes on router.
+func RegisterRoutes(router *mux.Router, userHandler *UserHandler, authHandler *AuthHandler, healthHandler *HealthHandler, userService *service.UserService) {
+	router.Use(middleware.Logging, middleware.Recovery, middleware.Metrics)
+
+	router.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
+
+	router.HandleFunc("/livez", healthHandler.Livez).Methods(http.MethodGet)
+	router.HandleFunc("/readyz", healthHandler.Readyz).Methods(http.MethodGet)
+
+	router.HandleFunc("/register", authHandler.Register).Methods(http.MethodPost)
+	router.HandleFunc("/login", authHandler.Login).Methods(http.MethodPost)
+	router.HandleFunc("/logout", authHandler.Logout).Methods(http.MethodPost)
+
+	users := router.PathPrefix("/users").Subrouter()
+	users.Use(RequireAuth(userService))
+	users.HandleFunc("", userHandler.GetAll).Methods(http.MethodGet)
+	users.HandleFunc("", userHandler.Create).Methods(http.MethodPost)
+	users.HandleFunc("/{id}", userHandler.GetByID).Methods(http.MethodGet)
+	users.HandleFunc("/{id}", userHandler.Update).Methods(http.MethodPut, http.MethodPatch)
+	users.HandleFunc("/{id}", userHandler.Delete).Methods(http.MethodDelete)
 }
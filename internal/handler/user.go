@@ -2,10 +2,15 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"api-test/internal/model"
 	"api-test/internal/service"
+	"api-test/internal/validation"
+
+	"github.com/gorilla/mux"
 )
 
 // UserHandler handles user-related HTTP requests.
@@ -25,15 +30,13 @@ func New(service *service.UserService) *UserHandler {
 //   - Status 500: Server error
 //   - Content-Type: application/json
 func (h *UserHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
 	users, err := h.service.GetAll(r.Context())
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeProblem(w, http.StatusInternalServerError, "Internal Server Error", err.Error(), r.URL.Path)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	if len(users) == 0 {
 		_ = json.NewEncoder(w).Encode([]model.User{})
 	} else {
@@ -41,10 +44,7 @@ func (h *UserHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetByID handles GET requests to /user and returns a single user by ID.
-//
-// Query Parameters:
-//   - id: The ID of the user to retrieve (required)
+// GetByID handles GET requests to /users/{id} and returns a single user by ID.
 //
 // HTTP Response:
 //   - Status 200: Successfully returns the requested user as JSON
@@ -53,27 +53,23 @@ func (h *UserHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 //   - Status 500: Server error
 //   - Content-Type: application/json
 func (h *UserHandler) GetByID(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
-	id := r.URL.Query().Get("id")
+	id := mux.Vars(r)["id"]
 	if id == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": "id parameter is required"})
+		writeProblem(w, http.StatusBadRequest, "Bad Request", "id parameter is required", r.URL.Path)
 		return
 	}
 
 	user, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		if err.Error() == "user not found" {
-			w.WriteHeader(http.StatusNotFound)
-			_ = json.NewEncoder(w).Encode(map[string]string{"error": "user not found"})
+		if errors.Is(err, service.ErrNotFound) {
+			writeProblem(w, http.StatusNotFound, "Not Found", "user not found", r.URL.Path)
 			return
 		}
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeProblem(w, http.StatusInternalServerError, "Internal Server Error", err.Error(), r.URL.Path)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(user)
 }
 
@@ -81,30 +77,104 @@ func (h *UserHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 //
 // Request Body:
 //
-//	Expects JSON with fields: name, email
-//	Example: {"name":"Charlie","email":"charlie@example.com"}
+//	Expects JSON with fields: name, email, password
+//	Example: {"name":"Charlie","email":"charlie@example.com","password":"s3cret"}
 //
 // HTTP Response:
 //   - Status 201: Successfully created user, returns the created user as JSON
-//   - Status 400: Invalid request body or missing required fields
+//   - Status 400: Invalid request body, unknown fields, or failed validation
 //   - Status 500: Server error
 //   - Content-Type: application/json
 func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
 	var user model.User
-	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid request body"})
+	if err := decodeJSONBody(w, r, &user); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Bad Request", "invalid request body", r.URL.Path)
+		return
+	}
+
+	if err := validation.Struct(user); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Validation Failed", err.Error(), r.URL.Path)
 		return
 	}
 
 	if err := h.service.Create(r.Context(), &user); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeProblem(w, http.StatusBadRequest, "Bad Request", err.Error(), r.URL.Path)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(user)
 }
+
+// Update handles PUT and PATCH requests to /users/{id} and updates an
+// existing user's name and email.
+//
+// Request Body:
+//
+//	Expects JSON with fields: name, email
+//
+// HTTP Response:
+//   - Status 200: Successfully updated user, returns the updated user as JSON
+//   - Status 400: Invalid request body, missing ID, unknown fields, or failed validation
+//   - Status 404: User not found
+//   - Status 500: Server error
+//   - Content-Type: application/json
+func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "Bad Request", "invalid id parameter", r.URL.Path)
+		return
+	}
+
+	var user model.User
+	if err := decodeJSONBody(w, r, &user); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Bad Request", "invalid request body", r.URL.Path)
+		return
+	}
+	user.ID = idInt
+
+	if err := validation.Struct(user); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Validation Failed", err.Error(), r.URL.Path)
+		return
+	}
+
+	if err := h.service.Update(r.Context(), &user); err != nil {
+		if errors.Is(err, service.ErrNotFound) {
+			writeProblem(w, http.StatusNotFound, "Not Found", "user not found", r.URL.Path)
+			return
+		}
+		writeProblem(w, http.StatusInternalServerError, "Internal Server Error", err.Error(), r.URL.Path)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(user)
+}
+
+// Delete handles DELETE requests to /users/{id} and removes a user.
+//
+// HTTP Response:
+//   - Status 204: Successfully deleted user
+//   - Status 400: Missing ID parameter
+//   - Status 404: User not found
+//   - Status 500: Server error
+func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		writeProblem(w, http.StatusBadRequest, "Bad Request", "id parameter is required", r.URL.Path)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, service.ErrNotFound) {
+			writeProblem(w, http.StatusNotFound, "Not Found", "user not found", r.URL.Path)
+			return
+		}
+		writeProblem(w, http.StatusInternalServerError, "Internal Server Error", err.Error(), r.URL.Path)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,64 @@
+// Package metrics holds the Prometheus collectors shared by the HTTP
+// middleware and the service layer, so both can record against the same
+// registered metrics without introducing an import cycle between them.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// HTTPRequestsTotal counts completed HTTP requests by route, method, and status.
+	HTTPRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests processed.",
+		},
+		[]string{"route", "method", "status"},
+	)
+
+	// HTTPRequestDuration observes request latency by route and method.
+	HTTPRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"route", "method"},
+	)
+
+	// HTTPRequestsInFlight tracks the number of requests currently being served.
+	HTTPRequestsInFlight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of HTTP requests currently being served.",
+		},
+	)
+
+	// DBQueryDuration observes database query latency by UserService operation.
+	DBQueryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Database query latency in seconds, by operation.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"operation"},
+	)
+
+	// CacheResultsTotal counts cache lookups by operation and outcome (hit/miss).
+	CacheResultsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_results_total",
+			Help: "Total number of cache lookups, by operation and outcome.",
+		},
+		[]string{"operation", "outcome"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+		DBQueryDuration,
+		CacheResultsTotal,
+	)
+}
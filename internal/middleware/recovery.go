@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// Recovery is HTTP middleware that recovers from panics in downstream
+// handlers, logs them with the request's ID, and responds with 500
+// instead of crashing the server.
+func Recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				requestID, _ := RequestIDFromContext(r.Context())
+				slog.Error("panic recovered", "request_id", requestID, "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -8,12 +8,16 @@ import "time"
 //   - ID: Unique identifier for the user
 //   - Name: Full name of the user
 //   - Email: Email address of the user
+//   - Password: Plaintext password, accepted on register and never persisted
+//   - PasswordHash: bcrypt hash of the password, never serialized
 //   - CreatedAt: Timestamp when user was created
 //   - UpdatedAt: Timestamp when user was last updated
 type User struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           int       `json:"id"`
+	Name         string    `json:"name" validate:"required,min=1,max=100"`
+	Email        string    `json:"email" validate:"required,email,max=254"`
+	Password     string    `json:"password,omitempty" validate:"omitempty,min=6,max=72"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
@@ -0,0 +1,6 @@
+package service
+
+import "errors"
+
+// ErrNotFound is returned when a requested user does not exist.
+var ErrNotFound = errors.New("user not found")
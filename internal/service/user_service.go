@@ -2,66 +2,151 @@ package service
 
 import (
 	"context"
-	"database/sql"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	mathrand "math/rand"
+	"strconv"
 	"time"
 
 	"api-test/internal/cache"
+	"api-test/internal/database"
+	"api-test/internal/metrics"
 	"api-test/internal/model"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/sync/singleflight"
 )
 
+// sessionKeyPrefix namespaces session tokens within the shared cache.
+const sessionKeyPrefix = "session:"
+
 // UserService handles business logic for users.
 type UserService struct {
-	db    *sql.DB
-	cache *cache.Client
+	repo        database.UserRepository
+	cache       *cache.Client
+	sessionTTL  time.Duration
+	allUsersTTL time.Duration
+	userTTL     time.Duration
+	jitterFrac  float64
+	sf          singleflight.Group
 }
 
-// New creates a new user service.
-func New(db *sql.DB, cache *cache.Client) *UserService {
+// New creates a new user service. allUsersTTL and userTTL are the base
+// (pre-jitter) freshness windows for the "all_users" and "user:{id}"
+// cache entries; jitterFrac is applied as ±jitterFrac to each.
+func New(repo database.UserRepository, cache *cache.Client, sessionTTL, allUsersTTL, userTTL time.Duration, jitterFrac float64) *UserService {
 	return &UserService{
-		db:    db,
-		cache: cache,
+		repo:        repo,
+		cache:       cache,
+		sessionTTL:  sessionTTL,
+		allUsersTTL: allUsersTTL,
+		userTTL:     userTTL,
+		jitterFrac:  jitterFrac,
+	}
+}
+
+// cacheEntry wraps a cached payload with the time at which it stops being
+// fresh, while remaining readable from Redis for a while longer so a
+// stale-but-recent value can still be served (stale-while-revalidate).
+type cacheEntry struct {
+	Data      json.RawMessage `json:"data"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+// jitteredTTL returns base adjusted by a random amount within ±jitterFrac,
+// to avoid many keys expiring in lockstep.
+func (s *UserService) jitteredTTL(base time.Duration) time.Duration {
+	if s.jitterFrac <= 0 {
+		return base
+	}
+	delta := float64(base) * s.jitterFrac * (2*mathrand.Float64() - 1)
+	return base + time.Duration(delta)
+}
+
+// cacheStore saves data under key with a jittered fresh TTL, keeping the
+// underlying Redis entry alive for twice as long so it can still be
+// served stale while a refresh runs in the background.
+func (s *UserService) cacheStore(ctx context.Context, key string, data []byte, freshTTL time.Duration) {
+	ttl := s.jitteredTTL(freshTTL)
+	entry := cacheEntry{Data: data, ExpiresAt: time.Now().Add(ttl)}
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return
 	}
+	_ = s.cache.Set(ctx, key, string(entryJSON), ttl*2)
 }
 
-// GetAll retrieves all users with caching.
+// cacheLoad returns the cached payload for key, if any, and whether it is
+// still within its freshness window.
+func (s *UserService) cacheLoad(ctx context.Context, key string) (data []byte, fresh bool, ok bool) {
+	raw, err := s.cache.Get(ctx, key)
+	if err != nil {
+		return nil, false, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+		return nil, false, false
+	}
+	return entry.Data, time.Now().Before(entry.ExpiresAt), true
+}
+
+// GetAll retrieves all users with caching. A hot key that has gone stale
+// is still served immediately while a single background goroutine
+// refreshes it (stale-while-revalidate); a fully missing key is loaded
+// synchronously through singleflight so concurrent callers collapse into
+// one database query instead of stampeding it.
 func (s *UserService) GetAll(ctx context.Context) ([]model.User, error) {
-	// Check cache first
-	cachedUsers, err := s.cache.Get(ctx, "all_users")
-	if err == nil {
+	const key = "all_users"
+
+	if data, fresh, ok := s.cacheLoad(ctx, key); ok {
 		var users []model.User
-		if err := json.Unmarshal([]byte(cachedUsers), &users); err == nil {
+		if err := json.Unmarshal(data, &users); err == nil {
+			metrics.CacheResultsTotal.WithLabelValues("get_all", "hit").Inc()
+			if !fresh {
+				go s.refreshAll(context.Background())
+			}
 			return users, nil
 		}
 	}
+	metrics.CacheResultsTotal.WithLabelValues("get_all", "miss").Inc()
 
-	// Cache miss - fetch from database
-	rows, err := s.db.Query("SELECT id, name, email, created_at, updated_at FROM users ORDER BY id")
+	v, err, _ := s.sf.Do(key, func() (interface{}, error) {
+		return s.loadAllFromRepo(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return v.([]model.User), nil
+}
 
-	var users []model.User
-	for rows.Next() {
-		var user model.User
-		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
-			return nil, err
-		}
-		users = append(users, user)
+func (s *UserService) loadAllFromRepo(ctx context.Context) ([]model.User, error) {
+	start := time.Now()
+	users, err := s.repo.GetAll(ctx)
+	metrics.DBQueryDuration.WithLabelValues("get_all").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, err
 	}
 
-	// Cache the result for 5 minutes
 	if len(users) > 0 {
-		usersJSON, _ := json.Marshal(users)
-		_ = s.cache.Set(ctx, "all_users", string(usersJSON), 5*time.Minute)
+		if usersJSON, err := json.Marshal(users); err == nil {
+			s.cacheStore(ctx, "all_users", usersJSON, s.allUsersTTL)
+		}
 	}
 
 	return users, nil
 }
 
-// GetByID retrieves a single user by ID with caching.
+func (s *UserService) refreshAll(ctx context.Context) {
+	_, _, _ = s.sf.Do("all_users", func() (interface{}, error) {
+		return s.loadAllFromRepo(ctx)
+	})
+}
+
+// GetByID retrieves a single user by ID with the same stale-while-revalidate
+// and singleflight protection as GetAll.
 func (s *UserService) GetByID(ctx context.Context, id string) (*model.User, error) {
 	if id == "" {
 		return nil, fmt.Errorf("id parameter is required")
@@ -69,58 +154,170 @@ func (s *UserService) GetByID(ctx context.Context, id string) (*model.User, erro
 
 	cacheKey := fmt.Sprintf("user:%s", id)
 
-	// Check cache first
-	cachedUser, err := s.cache.Get(ctx, cacheKey)
-	if err == nil {
+	if data, fresh, ok := s.cacheLoad(ctx, cacheKey); ok {
 		var user model.User
-		if err := json.Unmarshal([]byte(cachedUser), &user); err == nil {
+		if err := json.Unmarshal(data, &user); err == nil {
+			metrics.CacheResultsTotal.WithLabelValues("get_by_id", "hit").Inc()
+			if !fresh {
+				go s.refreshByID(context.Background(), id)
+			}
 			return &user, nil
 		}
 	}
+	metrics.CacheResultsTotal.WithLabelValues("get_by_id", "miss").Inc()
 
-	// Cache miss - fetch from database
-	var user model.User
-	err = s.db.QueryRow(
-		"SELECT id, name, email, created_at, updated_at FROM users WHERE id = $1",
-		id,
-	).Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt)
-
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("user not found")
+	v, err, _ := s.sf.Do(cacheKey, func() (interface{}, error) {
+		return s.loadByIDFromRepo(ctx, id)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return v.(*model.User), nil
+}
 
+func (s *UserService) loadByIDFromRepo(ctx context.Context, id string) (*model.User, error) {
+	start := time.Now()
+	user, err := s.repo.GetByID(ctx, id)
+	metrics.DBQueryDuration.WithLabelValues("get_by_id").Observe(time.Since(start).Seconds())
 	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, ErrNotFound
+		}
 		return nil, err
 	}
 
-	// Cache the result for 10 minutes
-	userJSON, _ := json.Marshal(user)
-	_ = s.cache.Set(ctx, cacheKey, string(userJSON), 10*time.Minute)
+	if userJSON, err := json.Marshal(user); err == nil {
+		s.cacheStore(ctx, fmt.Sprintf("user:%s", id), userJSON, s.userTTL)
+	}
+
+	return user, nil
+}
 
-	return &user, nil
+func (s *UserService) refreshByID(ctx context.Context, id string) {
+	cacheKey := fmt.Sprintf("user:%s", id)
+	_, _, _ = s.sf.Do(cacheKey, func() (interface{}, error) {
+		return s.loadByIDFromRepo(ctx, id)
+	})
 }
 
-// Create creates a new user.
+// Create creates a new user, hashing its password before insert.
 func (s *UserService) Create(ctx context.Context, user *model.User) error {
 	if user.Name == "" || user.Email == "" {
 		return fmt.Errorf("name and email are required")
 	}
+	if user.Password == "" {
+		return fmt.Errorf("password is required")
+	}
 
-	now := time.Now()
-	err := s.db.QueryRow(
-		"INSERT INTO users (name, email, created_at, updated_at) VALUES ($1, $2, $3, $4) RETURNING id",
-		user.Name, user.Email, now, now,
-	).Scan(&user.ID)
-
+	hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
+		return fmt.Errorf("failed to hash password: %w", err)
 	}
+	user.PasswordHash = string(hash)
 
-	user.CreatedAt = now
-	user.UpdatedAt = now
+	start := time.Now()
+	err = s.repo.Create(ctx, user)
+	metrics.DBQueryDuration.WithLabelValues("create").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return err
+	}
+	user.Password = ""
 
 	// Invalidate cache
 	_ = s.cache.Delete(ctx, "all_users")
 
 	return nil
 }
+
+// Update updates an existing user's name and email, invalidating both the
+// "all_users" and per-user cache entries.
+func (s *UserService) Update(ctx context.Context, user *model.User) error {
+	if user.Name == "" || user.Email == "" {
+		return fmt.Errorf("name and email are required")
+	}
+
+	start := time.Now()
+	err := s.repo.Update(ctx, user)
+	metrics.DBQueryDuration.WithLabelValues("update").Observe(time.Since(start).Seconds())
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	_ = s.cache.Delete(ctx, "all_users", fmt.Sprintf("user:%d", user.ID))
+
+	return nil
+}
+
+// Delete removes a user, invalidating both the "all_users" and per-user
+// cache entries.
+func (s *UserService) Delete(ctx context.Context, id string) error {
+	start := time.Now()
+	err := s.repo.Delete(ctx, id)
+	metrics.DBQueryDuration.WithLabelValues("delete").Observe(time.Since(start).Seconds())
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	_ = s.cache.Delete(ctx, "all_users", fmt.Sprintf("user:%s", id))
+
+	return nil
+}
+
+// Authenticate verifies the given email/password pair and, on success,
+// mints an opaque session token stored in the cache for sessionTTL.
+func (s *UserService) Authenticate(ctx context.Context, email, password string) (string, error) {
+	if email == "" || password == "" {
+		return "", fmt.Errorf("email and password are required")
+	}
+
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return "", fmt.Errorf("invalid email or password")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return "", fmt.Errorf("invalid email or password")
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	if err := s.cache.Set(ctx, sessionKeyPrefix+token, strconv.Itoa(user.ID), s.sessionTTL); err != nil {
+		return "", fmt.Errorf("failed to store session: %w", err)
+	}
+
+	return token, nil
+}
+
+// ResolveToken returns the user associated with a session token, or an
+// error if the token is missing, expired, or revoked.
+func (s *UserService) ResolveToken(ctx context.Context, token string) (*model.User, error) {
+	id, err := s.cache.Get(ctx, sessionKeyPrefix+token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid or expired session")
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// Logout revokes a session token so it can no longer be used to authenticate.
+func (s *UserService) Logout(ctx context.Context, token string) error {
+	return s.cache.Delete(ctx, sessionKeyPrefix+token)
+}
+
+// generateToken returns a random 32-byte opaque token, hex-encoded.
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
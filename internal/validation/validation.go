@@ -0,0 +1,50 @@
+// Package validation centralizes struct validation for incoming request
+// bodies using struct tags declared on the model types.
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate is safe for concurrent use and caches struct metadata, so a
+// single package-level instance is shared across all callers.
+var validate = validator.New()
+
+// Struct validates s against its "validate" struct tags and returns a
+// single error describing every failing field, or nil if s is valid.
+func Struct(s interface{}) error {
+	if err := validate.Struct(s); err != nil {
+		verrs, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return err
+		}
+
+		messages := make([]string, 0, len(verrs))
+		for _, fe := range verrs {
+			messages = append(messages, fieldMessage(fe))
+		}
+		return fmt.Errorf("%s", strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// fieldMessage renders a single field validation failure as a short,
+// human-readable message.
+func fieldMessage(fe validator.FieldError) string {
+	field := strings.ToLower(fe.Field())
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "min":
+		return fmt.Sprintf("%s must be at least %s characters", field, fe.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s characters", field, fe.Param())
+	default:
+		return fmt.Sprintf("%s is invalid", field)
+	}
+}
@@ -7,15 +7,18 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
 	"api-test/internal/cache"
+	"api-test/internal/database"
 	"api-test/internal/handler"
 	"api-test/internal/model"
 	"api-test/internal/service"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/gorilla/mux"
 )
 
 // setupTestDB initializes an in-memory SQLite database for testing.
@@ -32,6 +35,7 @@ func setupTestDB(t *testing.T) *sql.DB {
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		name VARCHAR(255) NOT NULL,
 		email VARCHAR(255) NOT NULL UNIQUE,
+		password_hash VARCHAR(255) NOT NULL DEFAULT '',
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);`
@@ -62,17 +66,18 @@ func setupTestRedis(t *testing.T) *cache.Client {
 	return &cache.Client{Underlying: redisClient}
 }
 
-// TestHealthCheck tests the health check endpoint.
+// TestHealthCheck tests the liveness probe endpoint.
 func TestHealthCheck(t *testing.T) {
-	req, err := http.NewRequest("GET", "/health", nil)
+	req, err := http.NewRequest("GET", "/livez", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(handler.Health)
+	healthHandler := handler.NewHealth(nil, nil)
+	h := http.HandlerFunc(healthHandler.Livez)
 
-	handler.ServeHTTP(rr, req)
+	h.ServeHTTP(rr, req)
 
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
@@ -96,7 +101,8 @@ func TestGetUsersEmpty(t *testing.T) {
 	// Mock Redis cache
 	mockCache := &cache.Client{Underlying: redis.NewClient(&redis.Options{Addr: "localhost:6379"})}
 
-	userService := service.New(testDB, mockCache)
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
 	userHandler := handler.New(userService)
 
 	req, err := http.NewRequest("GET", "/users", nil)
@@ -133,12 +139,14 @@ func TestCreateUser(t *testing.T) {
 
 	mockCache := &cache.Client{Underlying: redis.NewClient(&redis.Options{Addr: "localhost:6379"})}
 
-	userService := service.New(testDB, mockCache)
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
 	userHandler := handler.New(userService)
 
 	user := model.User{
-		Name:  "Test User",
-		Email: "test@example.com",
+		Name:     "Test User",
+		Email:    "test@example.com",
+		Password: "hunter2",
 	}
 
 	body, err := json.Marshal(user)
@@ -185,7 +193,8 @@ func TestCreateUserMissingFields(t *testing.T) {
 
 	mockCache := &cache.Client{Underlying: redis.NewClient(&redis.Options{Addr: "localhost:6379"})}
 
-	userService := service.New(testDB, mockCache)
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
 	userHandler := handler.New(userService)
 
 	testCases := []struct {
@@ -226,13 +235,13 @@ func TestCreateUserMissingFields(t *testing.T) {
 				t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
 			}
 
-			var errResponse map[string]string
+			var errResponse map[string]interface{}
 			if err := json.NewDecoder(rr.Body).Decode(&errResponse); err != nil {
 				t.Fatalf("Failed to decode response: %v", err)
 			}
 
-			if _, exists := errResponse["error"]; !exists {
-				t.Error("expected error field in response")
+			if _, exists := errResponse["detail"]; !exists {
+				t.Error("expected detail field in response")
 			}
 		})
 	}
@@ -245,7 +254,8 @@ func TestCreateUserInvalidJSON(t *testing.T) {
 
 	mockCache := &cache.Client{Underlying: redis.NewClient(&redis.Options{Addr: "localhost:6379"})}
 
-	userService := service.New(testDB, mockCache)
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
 	userHandler := handler.New(userService)
 
 	req, err := http.NewRequest("POST", "/users", bytes.NewBuffer([]byte("invalid json")))
@@ -263,6 +273,69 @@ func TestCreateUserInvalidJSON(t *testing.T) {
 	}
 }
 
+// TestRegisterAndLogin tests registering a user and then logging in with
+// the same credentials to obtain a session token.
+func TestRegisterAndLogin(t *testing.T) {
+	testDB := setupTestDB(t)
+	defer testDB.Close()
+
+	mockCache := setupTestRedis(t)
+
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
+	authHandler := handler.NewAuth(userService)
+
+	registerBody, err := json.Marshal(model.User{
+		Name:     "Test User",
+		Email:    "test@example.com",
+		Password: "hunter2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registerReq, err := http.NewRequest("POST", "/register", bytes.NewBuffer(registerBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registerRR := httptest.NewRecorder()
+	authHandler.Register(registerRR, registerReq)
+
+	if status := registerRR.Code; status != http.StatusCreated {
+		t.Fatalf("register returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	loginBody, err := json.Marshal(map[string]string{
+		"email":    "test@example.com",
+		"password": "hunter2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loginReq, err := http.NewRequest("POST", "/login", bytes.NewBuffer(loginBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loginRR := httptest.NewRecorder()
+	authHandler.Login(loginRR, loginReq)
+
+	if status := loginRR.Code; status != http.StatusOK {
+		t.Fatalf("login returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	var result map[string]string
+	if err := json.NewDecoder(loginRR.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if result["token"] == "" {
+		t.Error("expected non-empty token")
+	}
+}
+
 // TestGetUserNotFound tests getting a non-existent user.
 func TestGetUserNotFound(t *testing.T) {
 	testDB := setupTestDB(t)
@@ -270,13 +343,15 @@ func TestGetUserNotFound(t *testing.T) {
 
 	mockCache := &cache.Client{Underlying: redis.NewClient(&redis.Options{Addr: "localhost:6379"})}
 
-	userService := service.New(testDB, mockCache)
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
 	userHandler := handler.New(userService)
 
-	req, err := http.NewRequest("GET", "/user?id=999", nil)
+	req, err := http.NewRequest("GET", "/users/999", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
+	req = mux.SetURLVars(req, map[string]string{"id": "999"})
 
 	rr := httptest.NewRecorder()
 
@@ -291,8 +366,8 @@ func TestGetUserNotFound(t *testing.T) {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
-	if errResponse["error"] != "user not found" {
-		t.Errorf("expected 'user not found', got %s", errResponse["error"])
+	if errResponse["detail"] != "user not found" {
+		t.Errorf("expected 'user not found', got %s", errResponse["detail"])
 	}
 }
 
@@ -303,10 +378,11 @@ func TestGetUserMissingID(t *testing.T) {
 
 	mockCache := &cache.Client{Underlying: redis.NewClient(&redis.Options{Addr: "localhost:6379"})}
 
-	userService := service.New(testDB, mockCache)
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
 	userHandler := handler.New(userService)
 
-	req, err := http.NewRequest("GET", "/user", nil)
+	req, err := http.NewRequest("GET", "/users/", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -324,8 +400,8 @@ func TestGetUserMissingID(t *testing.T) {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
-	if errResponse["error"] != "id parameter is required" {
-		t.Errorf("expected 'id parameter is required', got %s", errResponse["error"])
+	if errResponse["detail"] != "id parameter is required" {
+		t.Errorf("expected 'id parameter is required', got %s", errResponse["detail"])
 	}
 }
 
@@ -336,7 +412,8 @@ func TestGetUserFound(t *testing.T) {
 
 	mockCache := &cache.Client{Underlying: redis.NewClient(&redis.Options{Addr: "localhost:6379"})}
 
-	userService := service.New(testDB, mockCache)
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
 	userHandler := handler.New(userService)
 
 	// Insert test user
@@ -347,11 +424,13 @@ func TestGetUserFound(t *testing.T) {
 	}
 
 	userID, _ := result.LastInsertId()
+	idStr := strconv.FormatInt(userID, 10)
 
-	req, err := http.NewRequest("GET", "/user?id="+string(rune(userID)), nil)
+	req, err := http.NewRequest("GET", "/users/"+idStr, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
+	req = mux.SetURLVars(req, map[string]string{"id": idStr})
 
 	rr := httptest.NewRecorder()
 
@@ -375,6 +454,90 @@ func TestGetUserFound(t *testing.T) {
 	}
 }
 
+// TestUpdateUser tests updating an existing user's name and email.
+func TestUpdateUser(t *testing.T) {
+	testDB := setupTestDB(t)
+	defer testDB.Close()
+
+	mockCache := &cache.Client{Underlying: redis.NewClient(&redis.Options{Addr: "localhost:6379"})}
+
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
+	userHandler := handler.New(userService)
+
+	insertSQL := `INSERT INTO users (name, email, created_at, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+	result, err := testDB.Exec(insertSQL, "Test User", "test@example.com")
+	if err != nil {
+		t.Fatalf("Failed to insert test user: %v", err)
+	}
+
+	userID, _ := result.LastInsertId()
+	idStr := strconv.FormatInt(userID, 10)
+
+	body, err := json.Marshal(model.User{Name: "Updated User", Email: "updated@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("PUT", "/users/"+idStr, bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = mux.SetURLVars(req, map[string]string{"id": idStr})
+
+	rr := httptest.NewRecorder()
+
+	userHandler.Update(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	var updated model.User
+	if err := json.NewDecoder(rr.Body).Decode(&updated); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if updated.Name != "Updated User" {
+		t.Errorf("expected name 'Updated User', got %s", updated.Name)
+	}
+}
+
+// TestDeleteUser tests deleting an existing user.
+func TestDeleteUser(t *testing.T) {
+	testDB := setupTestDB(t)
+	defer testDB.Close()
+
+	mockCache := &cache.Client{Underlying: redis.NewClient(&redis.Options{Addr: "localhost:6379"})}
+
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
+	userHandler := handler.New(userService)
+
+	insertSQL := `INSERT INTO users (name, email, created_at, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+	result, err := testDB.Exec(insertSQL, "Test User", "test@example.com")
+	if err != nil {
+		t.Fatalf("Failed to insert test user: %v", err)
+	}
+
+	userID, _ := result.LastInsertId()
+	idStr := strconv.FormatInt(userID, 10)
+
+	req, err := http.NewRequest("DELETE", "/users/"+idStr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = mux.SetURLVars(req, map[string]string{"id": idStr})
+
+	rr := httptest.NewRecorder()
+
+	userHandler.Delete(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+}
+
 // TestContentType tests that responses have correct Content-Type header.
 func TestContentType(t *testing.T) {
 	testDB := setupTestDB(t)
@@ -382,8 +545,10 @@ func TestContentType(t *testing.T) {
 
 	mockCache := &cache.Client{Underlying: redis.NewClient(&redis.Options{Addr: "localhost:6379"})}
 
-	userService := service.New(testDB, mockCache)
+	userRepo := database.NewSQLiteRepository(testDB)
+	userService := service.New(userRepo, mockCache, time.Hour, 5*time.Minute, 10*time.Minute, 0)
 	userHandler := handler.New(userService)
+	healthHandler := handler.NewHealth(nil, nil)
 
 	testCases := []struct {
 		name    string
@@ -400,11 +565,11 @@ func TestContentType(t *testing.T) {
 			},
 		},
 		{
-			name:   "GET /health",
+			name:   "GET /livez",
 			method: "GET",
-			path:   "/health",
+			path:   "/livez",
 			handler: func(w http.ResponseWriter, r *http.Request) {
-				handler.Health(w, r)
+				healthHandler.Livez(w, r)
 			},
 		},
 	}